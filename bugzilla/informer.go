@@ -48,15 +48,60 @@ func (s *BugLister) Get(id int) (*Bug, error) {
 	return obj.(*Bug), nil
 }
 
-func NewInformer(client *Client, interval, resyncInterval time.Duration, argsFn func(metav1.ListOptions) SearchBugsArgs, includeFn func(*BugInfo) bool) cache.SharedIndexInformer {
+// NewInformer builds a SharedIndexInformer backed by periodic bugzilla
+// searches. Besides the incremental Added/Modified events driven by
+// LastChangeTime, it also performs a full reconciliation every
+// reconcileInterval (if non-zero) that diffs the indexer's known keys
+// against a fresh List and emits Deleted events for bugs that have fallen
+// out of argsFn's query entirely, since those never show up as a
+// LastChangeTime change. If maxChunkSize is greater than zero, the initial
+// List is paginated through ListChunked instead of a single SearchBugs call.
+// metrics may be nil, in which case observability is a no-op.
+func NewInformer(client *Client, interval, resyncInterval, reconcileInterval time.Duration, maxChunkSize int, metrics MetricsProvider, argsFn func(metav1.ListOptions) SearchBugsArgs, includeFn func(*BugInfo) bool) *Informer {
+	if metrics == nil {
+		metrics = noopMetricsProvider{}
+	}
 	lw := &ListWatcher{
-		client:      client,
-		argsFn:      argsFn,
-		includeFn:   includeFn,
-		interval:    interval,
-		maxInterval: resyncInterval,
+		client:            client,
+		argsFn:            argsFn,
+		includeFn:         includeFn,
+		interval:          interval,
+		maxInterval:       resyncInterval,
+		reconcileInterval: reconcileInterval,
+		MaxChunkSize:      maxChunkSize,
+		metrics:           metrics,
+	}
+	shared := cache.NewSharedIndexInformer(lw, &Bug{}, resyncInterval, nil)
+	lw.knownKeysFn = shared.GetIndexer().ListKeys
+	lw.getByKeyFn = shared.GetIndexer().GetByKey
+	return &Informer{
+		SharedIndexInformer: shared,
+		lw:                  lw,
+		maxStaleness:        resyncInterval * 2,
+	}
+}
+
+// Informer wraps the SharedIndexInformer built by NewInformer with a Healthy
+// check suitable for backing an HTTP readiness probe.
+type Informer struct {
+	cache.SharedIndexInformer
+
+	lw           *ListWatcher
+	maxStaleness time.Duration
+}
+
+// Healthy returns a non-nil error if the informer hasn't completed a
+// successful List, incremental poll, or reconciliation within maxInterval*2,
+// meaning the bugzilla cache has likely fallen behind.
+func (i *Informer) Healthy() error {
+	last := i.lw.lastSyncTime()
+	if last.IsZero() {
+		return fmt.Errorf("bugzilla informer has not completed an initial sync yet")
+	}
+	if age := time.Since(last); age > i.maxStaleness {
+		return fmt.Errorf("bugzilla informer last synced %s ago, exceeding the %s staleness threshold", age.Round(time.Second), i.maxStaleness)
 	}
-	return cache.NewSharedIndexInformer(lw, &Bug{}, resyncInterval, nil)
+	return nil
 }
 
 type ListWatcher struct {
@@ -65,14 +110,202 @@ type ListWatcher struct {
 	includeFn   func(*BugInfo) bool
 	interval    time.Duration
 	maxInterval time.Duration
+
+	// reconcileInterval, if non-zero, enables a periodic full List that
+	// diffs against knownKeysFn to surface Deleted events.
+	reconcileInterval time.Duration
+	// knownKeysFn returns the keys currently held by the informer's
+	// indexer; it is wired up by NewInformer once the indexer exists.
+	knownKeysFn func() []string
+	// getByKeyFn looks up the cached object for a key in the informer's
+	// indexer, so reconciliation can emit a faithful Deleted object instead
+	// of a name-only stub; wired up alongside knownKeysFn.
+	getByKeyFn func(key string) (interface{}, bool, error)
+
+	// MaxChunkSize, if greater than zero, makes List paginate through
+	// ListChunked instead of issuing a single unbounded SearchBugs call.
+	MaxChunkSize int
+
+	metrics MetricsProvider
+
+	syncLock sync.Mutex
+	lastSync time.Time
+}
+
+// markSynced records that a List, incremental poll, or reconciliation pass
+// completed successfully with n items, for Healthy and MetricsProvider.
+func (lw *ListWatcher) markSynced(n int) {
+	now := time.Now()
+	lw.syncLock.Lock()
+	lw.lastSync = now
+	lw.syncLock.Unlock()
+	lw.metrics.ObserveItemsPerSync(n)
+	lw.metrics.SetLastSyncTime(now)
+}
+
+func (lw *ListWatcher) lastSyncTime() time.Time {
+	lw.syncLock.Lock()
+	defer lw.syncLock.Unlock()
+	return lw.lastSync
 }
 
 func (lw *ListWatcher) List(options metav1.ListOptions) (runtime.Object, error) {
+	if lw.MaxChunkSize > 0 {
+		return lw.listChunked(options)
+	}
+	lw.metrics.IncListTotal()
 	bugs, err := lw.client.SearchBugs(context.Background(), lw.argsFn(options))
 	if err != nil {
 		return nil, err
 	}
-	return NewBugList(bugs, lw.includeFn), nil
+	list := NewBugList(bugs, lw.includeFn)
+	lw.markSynced(len(list.Items))
+	var rv metav1.Time
+	if err := rv.UnmarshalQueryParameter(list.ResourceVersion); err == nil {
+		lw.metrics.SetResourceVersion(rv.Time)
+	}
+	return list, nil
+}
+
+// listChunked drives ListChunked to build up a single BugList, the shape the
+// Reflector backing a SharedIndexInformer expects from List, while keeping
+// each underlying SearchBugs call bounded to MaxChunkSize bugs.
+func (lw *ListWatcher) listChunked(options metav1.ListOptions) (runtime.Object, error) {
+	list := &BugList{}
+	rv, err := lw.ListChunked(context.Background(), options, lw.MaxChunkSize, func(bugs []*Bug) error {
+		for _, bug := range bugs {
+			list.Items = append(list.Items, *bug)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	list.ResourceVersion = rv
+	return list, nil
+}
+
+// ListChunked performs the equivalent of List, but instead of a single
+// SearchBugs call covering the whole of argsFn(options), it walks forward
+// through LastChangeTime, asking bugzilla itself to bound each response to
+// chunkSize bugs (via SearchBugsArgs.Limit) and invoking fn once per chunk in
+// chronological order. This keeps both the per-call response and the peak
+// client-side memory bounded for large queries, at the cost of more
+// SearchBugs round trips. The returned resourceVersion is the maximum
+// LastChangeTime observed across all chunks, suitable for a subsequent Watch
+// call to resume from.
+//
+// LastChangeTime queries are only second-granular (see periodicWatcher.run),
+// so a cursor can't always advance past a chunk: if more than chunkSize bugs
+// share the same LastChangeTime second, re-querying from that second repeats
+// the same bugs rather than paging further into them, since SearchBugsArgs
+// has no secondary sort key or offset to page within a second. A same-second
+// dedupe set filters those repeats out of each chunk, but if a whole chunk
+// comes back with nothing new and the second isn't exhausted, there's no way
+// to tell whether that's because every bug in the second has already been
+// delivered or because the remainder is unreachable with this chunkSize;
+// ListChunked reports the latter as an error instead of silently dropping
+// the undelivered bugs.
+func (lw *ListWatcher) ListChunked(ctx context.Context, options metav1.ListOptions, chunkSize int, fn func([]*Bug) error) (resourceVersion string, err error) {
+	if chunkSize <= 0 {
+		return "", fmt.Errorf("chunkSize must be greater than zero")
+	}
+
+	args := lw.argsFn(options)
+	cursor := args.LastChangeTime
+	var maxRV metav1.Time
+	var totalItems int
+
+	var boundarySecond time.Time
+	deliveredAtBoundary := map[string]struct{}{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return timeToRV(maxRV), ctx.Err()
+		default:
+		}
+
+		windowArgs := args
+		windowArgs.LastChangeTime = cursor
+		windowArgs.Limit = chunkSize
+		lw.metrics.IncListTotal()
+		bugs, err := lw.client.SearchBugs(ctx, windowArgs)
+		if err != nil {
+			return timeToRV(maxRV), err
+		}
+
+		list := NewBugList(bugs, lw.includeFn)
+		if len(list.Items) == 0 {
+			break
+		}
+
+		sort.Slice(list.Items, func(i, j int) bool {
+			return list.Items[i].Info.LastChangeTime.Time.Before(list.Items[j].Info.LastChangeTime.Time)
+		})
+
+		prevBoundary, deliveredAtPrev := boundarySecond, deliveredAtBoundary
+		lastTime := list.Items[len(list.Items)-1].Info.LastChangeTime.Time
+		newBoundary := lastTime.Truncate(time.Second)
+		if !newBoundary.Equal(boundarySecond) {
+			boundarySecond = newBoundary
+			deliveredAtBoundary = map[string]struct{}{}
+		}
+
+		fresh := list.Items[:0:0]
+		for _, bug := range list.Items {
+			second := bug.Info.LastChangeTime.Time.Truncate(time.Second)
+			if second.Equal(prevBoundary) {
+				if _, ok := deliveredAtPrev[bug.Name]; ok {
+					continue
+				}
+			}
+			if second.Equal(boundarySecond) {
+				if _, ok := deliveredAtBoundary[bug.Name]; ok {
+					continue
+				}
+				deliveredAtBoundary[bug.Name] = struct{}{}
+			}
+			fresh = append(fresh, bug)
+		}
+
+		exhausted := len(list.Items) < chunkSize
+
+		if len(fresh) == 0 {
+			if exhausted {
+				break
+			}
+			// A full chunk came back and every bug in it was already
+			// delivered. Since LastChangeTime only sorts to the second and
+			// SearchBugsArgs has no secondary key or offset, re-querying from
+			// boundarySecond will keep returning this same chunkSize-sized
+			// page forever: there is no way to reach the rest of the bugs
+			// sharing this second. Surface that instead of silently moving
+			// the cursor past undelivered bugs.
+			return timeToRV(maxRV), fmt.Errorf("more than %d bugs share LastChangeTime second %s and chunkSize is too small to page through them; increase chunkSize or narrow the query", chunkSize, boundarySecond)
+		}
+
+		items := make([]*Bug, len(fresh))
+		for i := range fresh {
+			items[i] = &fresh[i]
+			if fresh[i].Info.LastChangeTime.Time.After(maxRV.Time) {
+				maxRV = fresh[i].Info.LastChangeTime
+			}
+		}
+		if err := fn(items); err != nil {
+			return timeToRV(maxRV), err
+		}
+		totalItems += len(items)
+
+		if exhausted {
+			break
+		}
+		cursor = metav1.Time{Time: boundarySecond}
+	}
+
+	lw.markSynced(totalItems)
+	lw.metrics.SetResourceVersion(maxRV.Time)
+	return timeToRV(maxRV), nil
 }
 
 func (lw *ListWatcher) Watch(options metav1.ListOptions) (watch.Interface, error) {
@@ -80,40 +313,66 @@ func (lw *ListWatcher) Watch(options metav1.ListOptions) (watch.Interface, error
 	if err := rv.UnmarshalQueryParameter(options.ResourceVersion); err != nil {
 		return nil, err
 	}
-	return newPeriodicWatcher(lw, lw.interval, lw.maxInterval, rv, lw.argsFn(options), lw.includeFn), nil
+	lw.metrics.IncWatchTotal()
+	return newPeriodicWatcher(lw, lw.interval, lw.maxInterval, lw.reconcileInterval, rv, lw.argsFn(options), lw.includeFn, lw.knownKeysFn, lw.getByKeyFn), nil
 }
 
 type periodicWatcher struct {
-	lw          *ListWatcher
-	ch          chan watch.Event
-	interval    time.Duration
-	maxInterval time.Duration
-	rv          metav1.Time
-	args        SearchBugsArgs
-	includeFn   func(*BugInfo) bool
+	lw                *ListWatcher
+	ch                chan watch.Event
+	interval          time.Duration
+	maxInterval       time.Duration
+	reconcileInterval time.Duration
+	rv                metav1.Time
+	args              SearchBugsArgs
+	includeFn         func(*BugInfo) bool
+	knownKeysFn       func() []string
+	getByKeyFn        func(key string) (interface{}, bool, error)
 
 	lock   sync.Mutex
 	done   chan struct{}
 	closed bool
+
+	// emitLock serializes the incremental poll and the reconciliation pass
+	// so the two never race on rv or interleave writes to ch.
+	emitLock sync.Mutex
+
+	// reconcileWG tracks the runReconcile goroutine, so run can wait for a
+	// reconcile pass that's still sending on ch to finish before closing it.
+	reconcileWG sync.WaitGroup
 }
 
-func newPeriodicWatcher(lw *ListWatcher, interval, maxInterval time.Duration, rv metav1.Time, args SearchBugsArgs, includeFn func(*BugInfo) bool) *periodicWatcher {
+func newPeriodicWatcher(lw *ListWatcher, interval, maxInterval, reconcileInterval time.Duration, rv metav1.Time, args SearchBugsArgs, includeFn func(*BugInfo) bool, knownKeysFn func() []string, getByKeyFn func(string) (interface{}, bool, error)) *periodicWatcher {
 	pw := &periodicWatcher{
-		lw:          lw,
-		interval:    interval,
-		maxInterval: maxInterval,
-		rv:          rv,
-		args:        args,
-		ch:          make(chan watch.Event, 100),
-		done:        make(chan struct{}),
+		lw:                lw,
+		interval:          interval,
+		maxInterval:       maxInterval,
+		reconcileInterval: reconcileInterval,
+		rv:                rv,
+		args:              args,
+		includeFn:         includeFn,
+		knownKeysFn:       knownKeysFn,
+		getByKeyFn:        getByKeyFn,
+		ch:                make(chan watch.Event, 100),
+		done:              make(chan struct{}),
 	}
 	go pw.run()
+	if reconcileInterval > 0 && knownKeysFn != nil {
+		pw.reconcileWG.Add(1)
+		go pw.runReconcile()
+	}
 	return pw
 }
 
 func (w *periodicWatcher) run() {
 	defer klog.V(4).Infof("Watcher exited")
-	defer close(w.ch)
+	defer func() {
+		// runReconcile sends on ch too; wait for it to finish (including any
+		// reconcile pass still in flight) before we're the one who closes it,
+		// or a concurrent send there would panic on a closed channel.
+		w.reconcileWG.Wait()
+		close(w.ch)
+	}()
 
 	// never watch longer than maxInterval
 	stop := time.After(w.maxInterval)
@@ -121,6 +380,7 @@ func (w *periodicWatcher) run() {
 		select {
 		case <-stop:
 			klog.V(5).Infof("maximum duration reached %s", w.maxInterval)
+			w.lw.metrics.IncWatchCloseTotal("expired")
 			w.ch <- watch.Event{Type: watch.Error, Object: &errors.NewResourceExpired(fmt.Sprintf("watch closed after %s, resync required", w.maxInterval)).ErrStatus}
 			w.stop()
 		case <-w.done:
@@ -145,16 +405,21 @@ func (w *periodicWatcher) run() {
 	}
 
 	wait.Until(func() {
+		w.emitLock.Lock()
+		defer w.emitLock.Unlock()
+
 		args := w.args
 		args.LastChangeTime = rv.Time
 		bugs, err := w.lw.client.SearchBugs(context.Background(), args)
 		if err != nil {
 			klog.V(5).Infof("Search query error: %v", err)
+			w.lw.metrics.IncWatchCloseTotal("error")
 			w.ch <- watch.Event{Type: watch.Error, Object: &errors.NewInternalError(err).ErrStatus}
 			w.stop()
 			return
 		}
 		if len(bugs.Bugs) == 0 {
+			w.lw.markSynced(0)
 			return
 		}
 
@@ -191,15 +456,73 @@ func (w *periodicWatcher) run() {
 			w.ch <- watch.Event{Type: eventType, Object: &list.Items[i]}
 		}
 		rv = nextRV
+		w.lw.markSynced(len(list.Items))
+		w.lw.metrics.SetResourceVersion(rv.Time)
 	}, w.interval, w.done)
 }
 
+// runReconcile periodically performs a full List and diffs it against
+// knownKeysFn, emitting Deleted events for bugs that have fallen out of the
+// query entirely (e.g. a component or product change) and so would never be
+// observed by the incremental LastChangeTime loop.
+func (w *periodicWatcher) runReconcile() {
+	defer w.reconcileWG.Done()
+	wait.Until(w.reconcile, w.reconcileInterval, w.done)
+}
+
+func (w *periodicWatcher) reconcile() {
+	// take emitLock so the reconciliation pass never interleaves with the
+	// incremental poll's rv update and channel sends.
+	w.emitLock.Lock()
+	defer w.emitLock.Unlock()
+
+	obj, err := w.lw.List(metav1.ListOptions{})
+	if err != nil {
+		klog.V(5).Infof("Reconciliation list error: %v", err)
+		return
+	}
+	list := obj.(*BugList)
+	present := make(map[string]struct{}, len(list.Items))
+	for i := range list.Items {
+		present[list.Items[i].Name] = struct{}{}
+	}
+
+	for _, key := range w.knownKeysFn() {
+		if _, ok := present[key]; ok {
+			continue
+		}
+		klog.V(5).Infof("Reconciliation no longer observes %s, sending Deleted", key)
+		w.ch <- watch.Event{Type: watch.Deleted, Object: w.deletedBug(key)}
+	}
+}
+
+// deletedBug returns the best-effort Bug to report for a Deleted event: the
+// cached copy out of the indexer when available, falling back to a
+// name-only stub if it has already been evicted or getByKeyFn is unset.
+func (w *periodicWatcher) deletedBug(key string) *Bug {
+	if w.getByKeyFn != nil {
+		if obj, exists, err := w.getByKeyFn(key); err == nil && exists {
+			if bug, ok := obj.(*Bug); ok {
+				cp := *bug
+				return &cp
+			}
+		}
+	}
+	return &Bug{ObjectMeta: metav1.ObjectMeta{Name: key}}
+}
+
 func (w *periodicWatcher) Stop() {
 	defer func() {
 		// drain the channel if stop was invoked until the channel is closed
 		for range w.ch {
 		}
 	}()
+	w.lock.Lock()
+	alreadyClosed := w.closed
+	w.lock.Unlock()
+	if !alreadyClosed {
+		w.lw.metrics.IncWatchCloseTotal("stopped")
+	}
 	w.stop()
 	klog.V(4).Infof("Stopped watch")
 }
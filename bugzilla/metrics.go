@@ -0,0 +1,40 @@
+package bugzilla
+
+import "time"
+
+// MetricsProvider lets operators observe a bugzilla-backed informer the way
+// client-go's reflector exposes metrics for a Kubernetes informer: call
+// counts, why a watch closed, how many items came back per sync, and how
+// current the cache is. NewInformer accepts an implementation; passing nil
+// falls back to a no-op.
+type MetricsProvider interface {
+	// IncListTotal counts a single SearchBugs call made to satisfy a List
+	// (one call per page when chunked listing is in use).
+	IncListTotal()
+	// IncWatchTotal counts a new periodic watch being opened, including
+	// reconnects.
+	IncWatchTotal()
+	// IncWatchCloseTotal counts a watch closing, tagged with why: "expired"
+	// (maxInterval reached), "error" (a SearchBugs call failed), or
+	// "stopped" (the consumer called Stop).
+	IncWatchCloseTotal(reason string)
+	// ObserveItemsPerSync records how many bugs a List, incremental poll, or
+	// reconciliation pass observed.
+	ObserveItemsPerSync(n int)
+	// SetLastSyncTime records the wall-clock time of the last successful
+	// List, incremental poll, or reconciliation.
+	SetLastSyncTime(t time.Time)
+	// SetResourceVersion records the most recent resource version committed
+	// by the informer.
+	SetResourceVersion(rv time.Time)
+}
+
+// noopMetricsProvider is the default MetricsProvider: it discards everything.
+type noopMetricsProvider struct{}
+
+func (noopMetricsProvider) IncListTotal()                {}
+func (noopMetricsProvider) IncWatchTotal()               {}
+func (noopMetricsProvider) IncWatchCloseTotal(string)    {}
+func (noopMetricsProvider) ObserveItemsPerSync(int)      {}
+func (noopMetricsProvider) SetLastSyncTime(time.Time)    {}
+func (noopMetricsProvider) SetResourceVersion(time.Time) {}
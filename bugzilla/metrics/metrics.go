@@ -0,0 +1,93 @@
+// Package metrics provides a Prometheus-backed implementation of
+// bugzilla.MetricsProvider for operators who want to alert on a stale or
+// error-prone bugzilla informer cache.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Provider implements bugzilla.MetricsProvider with Prometheus collectors.
+// Register it once against a registry before passing it to
+// bugzilla.NewInformer.
+type Provider struct {
+	listTotal         prometheus.Counter
+	watchTotal        prometheus.Counter
+	watchCloseTotal   *prometheus.CounterVec
+	itemsPerSync      prometheus.Histogram
+	lastSyncTimestamp prometheus.Gauge
+	resourceVersion   prometheus.Gauge
+}
+
+// NewProvider constructs a Provider whose collectors are labeled with name
+// (e.g. the bugzilla query they track), so multiple informers can share a
+// registry without colliding.
+func NewProvider(name string) *Provider {
+	constLabels := prometheus.Labels{"informer": name}
+	return &Provider{
+		listTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   "bugzilla_informer",
+			Name:        "list_total",
+			Help:        "Total number of SearchBugs calls made to satisfy a List.",
+			ConstLabels: constLabels,
+		}),
+		watchTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   "bugzilla_informer",
+			Name:        "watch_total",
+			Help:        "Total number of periodic watches opened, including reconnects.",
+			ConstLabels: constLabels,
+		}),
+		watchCloseTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   "bugzilla_informer",
+			Name:        "watch_close_total",
+			Help:        "Total number of watches closed, by reason.",
+			ConstLabels: constLabels,
+		}, []string{"reason"}),
+		itemsPerSync: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace:   "bugzilla_informer",
+			Name:        "items_per_sync",
+			Help:        "Number of bugs observed in a single List, incremental poll, or reconciliation pass.",
+			ConstLabels: constLabels,
+			Buckets:     prometheus.ExponentialBuckets(1, 4, 8),
+		}),
+		lastSyncTimestamp: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   "bugzilla_informer",
+			Name:        "last_sync_timestamp_seconds",
+			Help:        "Unix timestamp of the last successful List, incremental poll, or reconciliation.",
+			ConstLabels: constLabels,
+		}),
+		resourceVersion: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   "bugzilla_informer",
+			Name:        "resource_version_timestamp_seconds",
+			Help:        "Unix timestamp of the most recent resource version committed by the informer.",
+			ConstLabels: constLabels,
+		}),
+	}
+}
+
+// Collectors returns every collector so callers can register them, e.g.
+// prometheus.MustRegister(p.Collectors()...).
+func (p *Provider) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		p.listTotal,
+		p.watchTotal,
+		p.watchCloseTotal,
+		p.itemsPerSync,
+		p.lastSyncTimestamp,
+		p.resourceVersion,
+	}
+}
+
+func (p *Provider) IncListTotal() { p.listTotal.Inc() }
+
+func (p *Provider) IncWatchTotal() { p.watchTotal.Inc() }
+
+func (p *Provider) IncWatchCloseTotal(reason string) { p.watchCloseTotal.WithLabelValues(reason).Inc() }
+
+func (p *Provider) ObserveItemsPerSync(n int) { p.itemsPerSync.Observe(float64(n)) }
+
+func (p *Provider) SetLastSyncTime(t time.Time) { p.lastSyncTimestamp.Set(float64(t.Unix())) }
+
+func (p *Provider) SetResourceVersion(rv time.Time) { p.resourceVersion.Set(float64(rv.Unix())) }
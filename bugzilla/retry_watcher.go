@@ -0,0 +1,324 @@
+package bugzilla
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+)
+
+const (
+	retryWatcherInitialBackoff = 1 * time.Second
+	retryWatcherMaxBackoff     = 30 * time.Second
+)
+
+// RetryWatcher wraps the periodic watch produced by ListWatcher.Watch and
+// transparently reconnects whenever the underlying watch closes with a
+// retryable error, so long-lived consumers (in particular the
+// SharedIndexInformer built by NewRetryingInformer) don't have to relist
+// every time a periodicWatcher hits its maxInterval or a transient
+// SearchBugs call fails.
+//
+// It is modeled on k8s.io/client-go's tools/watch.RetryWatcher.
+type RetryWatcher struct {
+	lw *ListWatcher
+	ch chan watch.Event
+
+	lock   sync.Mutex
+	lastRV metav1.Time
+	seen   map[string]metav1.Time // bug id -> last delivered LastChangeTime, deduped across reconnects
+
+	done   chan struct{}
+	closed bool
+}
+
+// NewRetryWatcher starts a watch from lw that keeps reconnecting: a watch
+// that closes with a retryable error (see isRetryableError) is transparently
+// re-opened from the last observed resource version after an exponential
+// backoff. Only a ResourceExpired (or Gone) error triggers a full relist. Any
+// other error is surfaced to the caller as a watch.Error event, after which
+// the RetryWatcher closes. It takes no context: Stop is the only way to end
+// it from outside.
+func NewRetryWatcher(lw *ListWatcher, initialRV metav1.Time) *RetryWatcher {
+	rw := &RetryWatcher{
+		lw:     lw,
+		ch:     make(chan watch.Event, 100),
+		done:   make(chan struct{}),
+		lastRV: initialRV,
+		seen:   make(map[string]metav1.Time),
+	}
+	go rw.run()
+	return rw
+}
+
+func (rw *RetryWatcher) run() {
+	defer klog.V(4).Infof("RetryWatcher exited")
+	defer close(rw.ch)
+
+	backoff := retryWatcherInitialBackoff
+	for {
+		select {
+		case <-rw.done:
+			return
+		default:
+		}
+
+		rw.lock.Lock()
+		rv := rw.lastRV
+		rw.lock.Unlock()
+
+		w, err := rw.lw.Watch(metav1.ListOptions{ResourceVersion: timeToRV(rv)})
+		if err != nil {
+			klog.V(4).Infof("RetryWatcher failed to open watch, retrying in %s: %v", backoff, err)
+			if !rw.sleep(&backoff) {
+				return
+			}
+			continue
+		}
+
+		terminal, stopped, delivered := rw.forward(w)
+		if stopped {
+			return
+		}
+		if delivered {
+			// the watch delivered at least one event before closing, so it was
+			// a working connection; don't let unrelated earlier failures keep
+			// inflating the backoff for a watch that is otherwise healthy.
+			backoff = retryWatcherInitialBackoff
+		}
+
+		switch {
+		case terminal == nil:
+			// the inner watch closed cleanly (e.g. stopped itself without an
+			// error event); treat it like any other disconnect and reconnect.
+			continue
+		case errors.IsResourceExpired(terminal) || errors.IsGone(terminal):
+			klog.V(4).Infof("RetryWatcher watch expired, relisting from scratch")
+			if !rw.relist() {
+				if !rw.sleep(&backoff) {
+					return
+				}
+				continue
+			}
+			backoff = retryWatcherInitialBackoff
+		case isRetryableError(terminal):
+			klog.V(4).Infof("RetryWatcher watch closed with a retryable error, reconnecting in %s: %v", backoff, terminal)
+			if !rw.sleep(&backoff) {
+				return
+			}
+		default:
+			rw.ch <- watch.Event{Type: watch.Error, Object: errStatus(terminal)}
+			return
+		}
+	}
+}
+
+// forward copies events from w to rw.ch, deduping Added/Modified events by
+// bug id + LastChangeTime and tracking the highest observed resource
+// version, until w closes. Deleted events bypass the dedupe entirely and
+// always forward: periodicWatcher's reconciliation emits them with the
+// bug's last-known LastChangeTime, which alreadyDelivered would otherwise
+// mistake for a repeat of the Added/Modified event already delivered for
+// that change, silently dropping every reconciled deletion. forward returns
+// the error the watch closed with (nil if it closed cleanly), whether
+// RetryWatcher.Stop was called in the meantime, and whether at least one
+// event was delivered (a signal the connection was actually healthy).
+func (rw *RetryWatcher) forward(w watch.Interface) (terminal error, stopped, delivered bool) {
+	defer w.Stop()
+	for {
+		select {
+		case <-rw.done:
+			return nil, true, delivered
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return nil, false, delivered
+			}
+			if event.Type == watch.Error {
+				return errorFromEvent(event), false, delivered
+			}
+			bug, ok := event.Object.(*Bug)
+			if !ok {
+				continue
+			}
+			if event.Type == watch.Deleted {
+				rw.ch <- event
+				rw.forgetDelivered(bug)
+				delivered = true
+				continue
+			}
+			if rw.alreadyDelivered(bug) {
+				continue
+			}
+			rw.ch <- event
+			rw.recordDelivered(bug)
+			delivered = true
+		}
+	}
+}
+
+func (rw *RetryWatcher) alreadyDelivered(bug *Bug) bool {
+	rw.lock.Lock()
+	defer rw.lock.Unlock()
+	last, ok := rw.seen[bug.Name]
+	return ok && !bug.Info.LastChangeTime.Time.After(last.Time)
+}
+
+func (rw *RetryWatcher) recordDelivered(bug *Bug) {
+	rw.lock.Lock()
+	defer rw.lock.Unlock()
+	rw.seen[bug.Name] = bug.Info.LastChangeTime
+	if bug.Info.LastChangeTime.Time.After(rw.lastRV.Time) {
+		rw.lastRV = bug.Info.LastChangeTime
+	}
+}
+
+// forgetDelivered evicts bug from the dedupe set after a Deleted event has
+// been forwarded for it, so a bug re-added later isn't mistaken for a repeat
+// of the change that preceded its deletion.
+func (rw *RetryWatcher) forgetDelivered(bug *Bug) {
+	rw.lock.Lock()
+	defer rw.lock.Unlock()
+	delete(rw.seen, bug.Name)
+}
+
+func (rw *RetryWatcher) relist() bool {
+	obj, err := rw.lw.List(metav1.ListOptions{})
+	if err != nil {
+		klog.V(4).Infof("RetryWatcher relist failed: %v", err)
+		return false
+	}
+	list := obj.(*BugList)
+	var nextRV metav1.Time
+	if err := nextRV.UnmarshalQueryParameter(list.ResourceVersion); err != nil {
+		klog.Errorf("RetryWatcher unable to parse resource version %q: %v", list.ResourceVersion, err)
+		return false
+	}
+	rw.lock.Lock()
+	rw.lastRV = nextRV
+	rw.lock.Unlock()
+	for i := range list.Items {
+		if rw.alreadyDelivered(&list.Items[i]) {
+			continue
+		}
+		rw.ch <- watch.Event{Type: watch.Added, Object: &list.Items[i]}
+		rw.recordDelivered(&list.Items[i])
+	}
+	return true
+}
+
+// sleep waits out the current backoff (with jitter), doubling it for next
+// time up to retryWatcherMaxBackoff. It returns false if Stop was called
+// while waiting.
+func (rw *RetryWatcher) sleep(backoff *time.Duration) bool {
+	jitter := time.Duration(rand.Int63n(int64(*backoff) / 2))
+	select {
+	case <-time.After(*backoff + jitter):
+	case <-rw.done:
+		return false
+	}
+	if *backoff *= 2; *backoff > retryWatcherMaxBackoff {
+		*backoff = retryWatcherMaxBackoff
+	}
+	return true
+}
+
+func (rw *RetryWatcher) Stop() {
+	defer func() {
+		for range rw.ch {
+		}
+	}()
+	rw.lock.Lock()
+	defer rw.lock.Unlock()
+	if !rw.closed {
+		close(rw.done)
+		rw.closed = true
+	}
+}
+
+func (rw *RetryWatcher) ResultChan() <-chan watch.Event {
+	return rw.ch
+}
+
+// isRetryableError reports whether err represents a transient condition
+// (network error, server timeout, rate limiting, or a 5xx from bugzilla)
+// that warrants reconnecting rather than surfacing the failure.
+func isRetryableError(err error) bool {
+	switch {
+	case errors.IsInternalError(err),
+		errors.IsServerTimeout(err),
+		errors.IsTimeout(err),
+		errors.IsTooManyRequests(err),
+		errors.IsServiceUnavailable(err),
+		errors.IsUnexpectedServerError(err):
+		return true
+	}
+	return false
+}
+
+func errorFromEvent(event watch.Event) error {
+	status := errStatus(nil)
+	if s, ok := event.Object.(*metav1.Status); ok {
+		status = s
+	}
+	return &errors.StatusError{ErrStatus: *status}
+}
+
+func errStatus(err error) *metav1.Status {
+	if err == nil {
+		return &metav1.Status{}
+	}
+	if se, ok := err.(*errors.StatusError); ok {
+		return &se.ErrStatus
+	}
+	return &metav1.Status{Message: err.Error()}
+}
+
+// NewRetryingInformer behaves like NewInformer, except the watch it feeds to
+// the SharedIndexInformer is backed by a RetryWatcher, so the informer's
+// cache survives watch interruptions (including the periodicWatcher's own
+// maxInterval expiry) without the informer relisting and replacing it. On
+// expiry the RetryWatcher still issues a full List itself, forwarding as
+// Added events only the bugs it has not already delivered.
+func NewRetryingInformer(client *Client, interval, resyncInterval, reconcileInterval time.Duration, maxChunkSize int, metrics MetricsProvider, argsFn func(metav1.ListOptions) SearchBugsArgs, includeFn func(*BugInfo) bool) *Informer {
+	if metrics == nil {
+		metrics = noopMetricsProvider{}
+	}
+	lw := &ListWatcher{
+		client:            client,
+		argsFn:            argsFn,
+		includeFn:         includeFn,
+		interval:          interval,
+		maxInterval:       resyncInterval,
+		reconcileInterval: reconcileInterval,
+		MaxChunkSize:      maxChunkSize,
+		metrics:           metrics,
+	}
+	retryingLW := &retryingListWatcher{ListWatcher: lw}
+	shared := cache.NewSharedIndexInformer(retryingLW, &Bug{}, resyncInterval, nil)
+	lw.knownKeysFn = shared.GetIndexer().ListKeys
+	lw.getByKeyFn = shared.GetIndexer().GetByKey
+	return &Informer{
+		SharedIndexInformer: shared,
+		lw:                  lw,
+		maxStaleness:        resyncInterval * 2,
+	}
+}
+
+// retryingListWatcher is a ListWatcher whose Watch method reconnects on
+// retryable errors; see RetryWatcher for the reconnect semantics.
+type retryingListWatcher struct {
+	*ListWatcher
+}
+
+func (lw *retryingListWatcher) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	var rv metav1.Time
+	if err := rv.UnmarshalQueryParameter(options.ResourceVersion); err != nil {
+		return nil, err
+	}
+	return NewRetryWatcher(lw.ListWatcher, rv), nil
+}
@@ -0,0 +1,135 @@
+package bugzilla
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// InformerWatcher adapts a cache.SharedIndexInformer into a watch.Interface
+// by subscribing to it with AddEventHandler and translating the informer's
+// callbacks into Added/Modified/Deleted events on a buffered channel.
+//
+// The informer package this repo vendors predates handler deregistration, so
+// Stop doesn't unregister the handler; it just stops forwarding and closes
+// the channel, leaving the (now inert) handler attached to the informer for
+// its lifetime.
+type InformerWatcher struct {
+	ch   chan watch.Event
+	done chan struct{}
+
+	lock    sync.RWMutex
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+// NewInformerWatcher subscribes to informer and returns a watch.Interface
+// that emits an event for every AddFunc/UpdateFunc/DeleteFunc callback the
+// informer fires from the moment of subscription onward.
+func NewInformerWatcher(informer cache.SharedIndexInformer) *InformerWatcher {
+	iw := &InformerWatcher{
+		ch:   make(chan watch.Event, 100),
+		done: make(chan struct{}),
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			iw.send(watch.Added, obj)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			iw.send(watch.Modified, obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			iw.send(watch.Deleted, obj)
+		},
+	})
+	return iw
+}
+
+// send blocks until the event is delivered, applying backpressure to the
+// informer's shared delivery goroutine when the consumer falls behind,
+// mirroring apimachinery's watch.InformerWatcher. This matters for
+// UntilWithInformer in particular: a handler added to an already-started
+// SharedIndexInformer is first replayed the whole cache as Added events, and
+// dropping any of those could silently discard the event a condition is
+// waiting on. A send already admitted past the stopped check still has to be
+// cancellable, though: Stop closes done so a send stuck on a full ch with no
+// one left to drain it is released instead of deadlocking Stop's wg.Wait.
+func (iw *InformerWatcher) send(t watch.EventType, obj interface{}) {
+	bug, ok := obj.(*Bug)
+	if !ok {
+		return
+	}
+	iw.lock.RLock()
+	if iw.stopped {
+		iw.lock.RUnlock()
+		return
+	}
+	iw.wg.Add(1)
+	iw.lock.RUnlock()
+	defer iw.wg.Done()
+	select {
+	case iw.ch <- watch.Event{Type: t, Object: bug}:
+	case <-iw.done:
+	}
+}
+
+func (iw *InformerWatcher) Stop() {
+	iw.lock.Lock()
+	if iw.stopped {
+		iw.lock.Unlock()
+		return
+	}
+	iw.stopped = true
+	iw.lock.Unlock()
+	// release any send already in flight (admitted before stopped was set)
+	// that's blocked on a full ch, then wait for it to finish before
+	// closing, so we never close a channel someone is still writing to.
+	close(iw.done)
+	iw.wg.Wait()
+	close(iw.ch)
+}
+
+func (iw *InformerWatcher) ResultChan() <-chan watch.Event {
+	return iw.ch
+}
+
+// UntilWithInformer mirrors k8s.io/apimachinery's watch.UntilWithInformer: it
+// subscribes a temporary watch to informer and feeds Added/Modified/Deleted
+// events through conditions in order, advancing to the next condition every
+// time one returns (true, nil). It returns the *Bug from the event that
+// satisfied the last condition, or an error if a condition returns one, the
+// informer's watch closes, or ctx is cancelled first.
+func UntilWithInformer(ctx context.Context, informer cache.SharedIndexInformer, conditions ...func(watch.Event) (bool, error)) (*Bug, error) {
+	w := NewInformerWatcher(informer)
+	defer w.Stop()
+
+	var lastBug *Bug
+	for _, condition := range conditions {
+		done := false
+		for !done {
+			select {
+			case <-ctx.Done():
+				return lastBug, ctx.Err()
+			case event, ok := <-w.ResultChan():
+				if !ok {
+					return lastBug, fmt.Errorf("informer watch closed before all conditions were met")
+				}
+				ok, err := condition(event)
+				if err != nil {
+					return lastBug, err
+				}
+				if bug, isBug := event.Object.(*Bug); isBug {
+					lastBug = bug
+				}
+				done = ok
+			}
+		}
+	}
+	return lastBug, nil
+}